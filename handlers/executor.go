@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/target/flottbot/models"
+)
+
+// Supported values for Action.Runtime. Shell is the default and preserves
+// the historical exec.CommandContext behavior.
+const (
+	RuntimeShell  = "shell"
+	RuntimeDocker = "docker"
+	RuntimePodman = "podman"
+	RuntimeWasm   = "wasm"
+)
+
+// ExecKind distinguishes why a script execution ended the way it did, so
+// callers can branch on "timed out" vs. "killed by signal" vs. "exited
+// non-zero" instead of guessing from a bare status int.
+type ExecKind int
+
+// Possible outcomes of a script execution.
+const (
+	ExecKindExited ExecKind = iota
+	ExecKindTimeout
+	ExecKindSignaled
+)
+
+// ExecError wraps the underlying execution error with the ExecKind that
+// produced it. It is returned alongside the *models.ScriptResponse from
+// an executor so rules can react differently to a timeout than to a
+// plain non-zero exit.
+type ExecError struct {
+	Kind ExecKind
+	Err  error
+}
+
+func (e *ExecError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExecError) Unwrap() error {
+	return e.Err
+}
+
+// executor runs a single rule action and produces a ScriptResponse. Each
+// Action.Runtime value is backed by exactly one executor implementation.
+type executor interface {
+	exec(ctx context.Context, args models.Action, msg *models.Message, bot *models.Bot) (*models.ScriptResponse, error)
+}
+
+// executorFor resolves the executor backend for an action's Runtime
+// field, defaulting to the shell backend for backwards compatibility
+// with rules that predate the Runtime field.
+func executorFor(runtime string) (executor, error) {
+	switch runtime {
+	case "", RuntimeShell:
+		return shellExecutor{}, nil
+	case RuntimeDocker, RuntimePodman:
+		return containerExecutor{engine: runtime}, nil
+	case RuntimeWasm:
+		return wasmExecutor{}, nil
+	default:
+		return nil, fmt.Errorf("unknown action runtime '%s'", runtime)
+	}
+}
+
+// concurrency tracks the number of in-flight executions per action name
+// so an action's MaxConcurrent cap can be enforced regardless of which
+// executor backend handles the action. The count is keyed by action
+// name only, so actions with the same name in different rules share it.
+var concurrency = struct {
+	mu    sync.Mutex
+	inUse map[string]int
+}{inUse: map[string]int{}}
+
+// acquireSlot reserves an execution slot for the named action, returning
+// false if the action's MaxConcurrent limit has already been reached. A
+// limit of 0 means unlimited, matching the zero-value default for
+// actions that don't set it.
+func acquireSlot(action string, limit int) bool {
+	if limit <= 0 {
+		return true
+	}
+
+	concurrency.mu.Lock()
+	defer concurrency.mu.Unlock()
+
+	if concurrency.inUse[action] >= limit {
+		return false
+	}
+
+	concurrency.inUse[action]++
+
+	return true
+}
+
+func releaseSlot(action string, limit int) {
+	if limit <= 0 {
+		return
+	}
+
+	concurrency.mu.Lock()
+	defer concurrency.mu.Unlock()
+
+	concurrency.inUse[action]--
+}
+
+// truncateOutput enforces args.MaxOutputBytes, the per-action cap on how
+// much stdout/stderr a script is allowed to return to the rule.
+func truncateOutput(output string, max int) string {
+	if max <= 0 || len(output) <= max {
+		return output
+	}
+
+	return output[:max] + "... (truncated)"
+}
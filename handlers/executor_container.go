@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/target/flottbot/models"
+	"github.com/target/flottbot/utils"
+)
+
+// containerExecutor runs the action command inside a named container
+// image via the docker or podman CLI, backing Action.Runtime ==
+// "docker"/"podman". It shells out to the engine binary rather than
+// linking a client SDK, the same way shellExecutor shells out to the
+// action's own command.
+type containerExecutor struct {
+	engine string // "docker" or "podman"
+}
+
+func (c containerExecutor) exec(ctx context.Context, args models.Action, msg *models.Message, bot *models.Bot) (*models.ScriptResponse, error) {
+	result := &models.ScriptResponse{
+		Status: 1,
+	}
+
+	if args.Image == "" {
+		return result, fmt.Errorf("action '%s' has runtime '%s' but no image set", args.Name, c.engine)
+	}
+
+	cmdProcessed, err := utils.Substitute(args.Cmd, msg.Vars)
+	if err != nil {
+		return result, err
+	}
+
+	runArgs := []string{"run", "--rm", "--network", containerNetworkMode(args), "--pull=missing"}
+
+	for _, mount := range args.Mounts {
+		runArgs = append(runArgs, "-v", mount)
+	}
+
+	for _, name := range args.EnvAllowList {
+		runArgs = append(runArgs, "-e", name)
+	}
+
+	// MaxCPUs is a core count (fractional cores allowed, e.g. 0.5),
+	// distinct from MaxCPUSeconds which caps CPU time in seconds for
+	// the shell executor via rlimit - the engine's --cpus flag has no
+	// notion of "seconds".
+	if args.MaxCPUs > 0 {
+		runArgs = append(runArgs, "--cpus", strconv.FormatFloat(args.MaxCPUs, 'f', -1, 64))
+	}
+
+	if args.MaxMemoryBytes > 0 {
+		runArgs = append(runArgs, "--memory", fmt.Sprintf("%d", args.MaxMemoryBytes))
+	}
+
+	runArgs = append(runArgs, args.Image, "sh", "-c", cmdProcessed)
+
+	cmd := exec.CommandContext(ctx, c.engine, runArgs...)
+
+	var stdout, stderr strings.Builder
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err = cmd.Run()
+
+	if ctx.Err() == context.DeadlineExceeded {
+		result.Output = "Hmm, something timed out. Please try again."
+		return result, &ExecError{Kind: ExecKindTimeout, Err: fmt.Errorf("timeout reached, %s exec for action '%s' cancelled", c.engine, args.Name)}
+	}
+
+	if err != nil {
+		result.Status = exitStatus(err)
+		result.Output = truncateOutput(strings.Trim(stderr.String(), " \n"), args.MaxOutputBytes)
+
+		kind := ExecKindExited
+		if result.Status == -1 {
+			kind = ExecKindSignaled
+		}
+
+		return result, &ExecError{Kind: kind, Err: err}
+	}
+
+	result.Status = 0
+	result.Output = truncateOutput(strings.Trim(stdout.String(), " \n"), args.MaxOutputBytes)
+
+	bot.Log.Info().Msgf("%s process finished for action '%s'", c.engine, args.Name)
+
+	return result, nil
+}
+
+// containerNetworkMode returns the engine network flag value for the
+// action, defaulting to "none" so rule scripts can't reach the network
+// unless a rule author opts in.
+func containerNetworkMode(args models.Action) string {
+	if args.NetworkMode == "" {
+		return "none"
+	}
+
+	return args.NetworkMode
+}
+
+func exitStatus(err error) int {
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return exitErr.ExitCode()
+	}
+
+	return 1
+}
@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/target/flottbot/models"
+)
+
+func TestContainerNetworkMode(t *testing.T) {
+	tests := []struct {
+		name string
+		args models.Action
+		want string
+	}{
+		{"defaults to none", models.Action{}, "none"},
+		{"respects an explicit mode", models.Action{NetworkMode: "bridge"}, "bridge"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := containerNetworkMode(tt.args); got != tt.want {
+				t.Errorf("containerNetworkMode(%+v) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
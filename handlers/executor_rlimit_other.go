@@ -0,0 +1,9 @@
+//go:build !unix
+
+package handlers
+
+import "github.com/target/flottbot/models"
+
+// rlimitWrapper is a no-op on non-Unix platforms; prlimit(1) has no
+// equivalent there.
+func rlimitWrapper(args models.Action) []string { return nil }
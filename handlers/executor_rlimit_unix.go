@@ -0,0 +1,35 @@
+//go:build unix
+
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/target/flottbot/models"
+)
+
+// rlimitWrapper returns the argv prefix needed to cap the child
+// process's CPU time and address space before the action's own command
+// runs, or nil if neither limit is set. It shells out to prlimit(1)
+// rather than calling syscall.Setrlimit directly: Setrlimit only ever
+// applies to the calling process, so calling it here (in flottbot
+// itself, before any child exists) would permanently lower the bot's
+// own limits instead of the script's. prlimit sets the limits on itself
+// and then execs the real command, so only that child inherits them.
+func rlimitWrapper(args models.Action) []string {
+	if args.MaxCPUSeconds == 0 && args.MaxMemoryBytes == 0 {
+		return nil
+	}
+
+	wrapper := []string{"prlimit"}
+
+	if args.MaxCPUSeconds > 0 {
+		wrapper = append(wrapper, fmt.Sprintf("--cpu=%d", args.MaxCPUSeconds))
+	}
+
+	if args.MaxMemoryBytes > 0 {
+		wrapper = append(wrapper, fmt.Sprintf("--as=%d", args.MaxMemoryBytes))
+	}
+
+	return append(wrapper, "--")
+}
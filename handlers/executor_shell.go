@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+
+	"github.com/target/flottbot/models"
+	"github.com/target/flottbot/utils"
+)
+
+// shellExecutor runs the action command directly on the host via
+// exec.CommandContext. This is the historical, default behavior of
+// ScriptExec and backs Action.Runtime == "shell" (or unset).
+type shellExecutor struct{}
+
+func (shellExecutor) exec(ctx context.Context, args models.Action, msg *models.Message, bot *models.Bot) (*models.ScriptResponse, error) {
+	result := &models.ScriptResponse{
+		Status: 1, // Default is exit code 1 (error)
+	}
+
+	// Deal with variable substitution in command
+	bot.Log.Debug().Msgf("command is: [%s]", args.Cmd)
+	cmdProcessed, err := utils.Substitute(args.Cmd, msg.Vars)
+	bot.Log.Debug().Msgf("substituted: [%s]", cmdProcessed)
+	if err != nil {
+		return result, err
+	}
+
+	// Parse out all the arguments from the supplied command, wrapped
+	// (best-effort, Unix only) so a misbehaving rule script can't
+	// exhaust host memory/CPU. The wrapper caps the command prlimit
+	// execs, never the flottbot process running this code.
+	bin := append(rlimitWrapper(args), utils.ExecArgTokenizer(cmdProcessed)...)
+	// Execute the command + arguments with the context
+	cmd := exec.CommandContext(ctx, bin[0], bin[1:]...)
+
+	// Capture stdout/stderr
+	out, err := cmd.Output()
+
+	// Handle timeouts
+	if ctx.Err() == context.DeadlineExceeded {
+		result.Output = "Hmm, something timed out. Please try again."
+		execErr := fmt.Errorf("timeout reached, exec process for action '%s' cancelled", args.Name)
+
+		return result, &ExecError{Kind: ExecKindTimeout, Err: execErr}
+	}
+
+	// Deal with non-zero exit codes
+	if err != nil {
+		switch err.(type) {
+		case *exec.ExitError:
+			ws := err.(*exec.ExitError).Sys().(syscall.WaitStatus)
+			stderr := strings.Trim(string(err.(*exec.ExitError).Stderr), " \n")
+			bot.Log.Debug().Str("correlation_id", msg.CorrelationID).Msgf("process for action '%s' exited with status '%d': %s", args.Name, ws.ExitStatus(), stderr)
+			result.Status = ws.ExitStatus()
+			result.Output = stderr
+
+			kind := ExecKindExited
+			if ws.Signaled() {
+				kind = ExecKindSignaled
+			}
+
+			strOut := strings.Trim(string(out), " \n")
+			if strOut != "" {
+				result.Output = strOut
+			}
+
+			result.Output = truncateOutput(result.Output, args.MaxOutputBytes)
+
+			return result, &ExecError{Kind: kind, Err: err}
+		case *os.PathError:
+			bot.Log.Debug().Msgf("process for action '%s' exited with status '%d': %v", args.Name, result.Status, err)
+			result.Status = 127
+			result.Output = err.Error()
+		default:
+			// this should rarely/never get hit
+			bot.Log.Debug().Msgf("couldn't get exit status for action '%s'", args.Name)
+			result.Output = strings.Trim(err.Error(), " \n")
+		}
+		// if something was printed to stdout before the error, use that as output
+		strOut := strings.Trim(string(out), " \n")
+		if strOut != "" {
+			result.Output = strOut
+		}
+
+		result.Output = truncateOutput(result.Output, args.MaxOutputBytes)
+
+		return result, err
+	}
+
+	// should be exit code 0 here
+	bot.Log.Info().Str("correlation_id", msg.CorrelationID).Msgf("process finished for action '%s'", args.Name)
+	ws := cmd.ProcessState.Sys().(syscall.WaitStatus)
+	result.Status = ws.ExitStatus()
+	result.Output = truncateOutput(strings.Trim(string(out), " \n"), args.MaxOutputBytes)
+
+	return result, nil
+}
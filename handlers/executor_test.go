@@ -0,0 +1,25 @@
+package handlers
+
+import "testing"
+
+func TestTruncateOutput(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		max    int
+		want   string
+	}{
+		{"no cap", "hello world", 0, "hello world"},
+		{"under cap", "hello", 10, "hello"},
+		{"exact cap", "hello", 5, "hello"},
+		{"over cap", "hello world", 5, "hello... (truncated)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := truncateOutput(tt.output, tt.max); got != tt.want {
+				t.Errorf("truncateOutput(%q, %d) = %q, want %q", tt.output, tt.max, got, tt.want)
+			}
+		})
+	}
+}
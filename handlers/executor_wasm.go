@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+
+	"github.com/target/flottbot/models"
+	"github.com/target/flottbot/utils"
+)
+
+// wasmExecutor runs a WASI module via wazero, backing Action.Runtime ==
+// "wasm". args.Cmd is expected to point at the compiled .wasm module on
+// disk; args.WasmArgs (after substitution) is passed through as argv.
+type wasmExecutor struct{}
+
+// wasmPageSize is the fixed size of a wazero linear memory page, used to
+// convert args.MaxMemoryBytes into the page count WithMemoryLimitPages
+// expects.
+const wasmPageSize = 64 * 1024
+
+func (wasmExecutor) exec(ctx context.Context, args models.Action, msg *models.Message, bot *models.Bot) (*models.ScriptResponse, error) {
+	result := &models.ScriptResponse{
+		Status: 1,
+	}
+
+	modPath, err := utils.Substitute(args.Cmd, msg.Vars)
+	if err != nil {
+		return result, err
+	}
+
+	wasmBytes, err := os.ReadFile(modPath)
+	if err != nil {
+		return result, fmt.Errorf("could not read wasm module for action '%s': %w", args.Name, err)
+	}
+
+	// wazero has no CPU metering knob, so args.MaxCPUSeconds (the
+	// rlimitWrapper cap applied to shell actions) is not enforced here -
+	// only the timeout carried on ctx bounds how long a wasm action can
+	// run. args.MaxMemoryBytes is enforced below via wazero's
+	// page-granular memory limit.
+	runtimeConfig := wazero.NewRuntimeConfig().WithCloseOnContextDone(true)
+	if args.MaxMemoryBytes > 0 {
+		runtimeConfig = runtimeConfig.WithMemoryLimitPages(uint32((args.MaxMemoryBytes + wasmPageSize - 1) / wasmPageSize))
+	}
+
+	runtime := wazero.NewRuntimeWithConfig(ctx, runtimeConfig)
+	defer runtime.Close(ctx)
+
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, runtime); err != nil {
+		return result, fmt.Errorf("could not instantiate WASI for action '%s': %w", args.Name, err)
+	}
+
+	argv := []string{modPath}
+	for _, arg := range args.WasmArgs {
+		argProcessed, err := utils.Substitute(arg, msg.Vars)
+		if err != nil {
+			return result, err
+		}
+
+		argv = append(argv, argProcessed)
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	cfg := wazero.NewModuleConfig().
+		WithStdout(&stdout).
+		WithStderr(&stderr).
+		WithArgs(argv...)
+
+	_, err = runtime.InstantiateWithConfig(ctx, wasmBytes, cfg)
+
+	if ctx.Err() == context.DeadlineExceeded {
+		result.Output = "Hmm, something timed out. Please try again."
+		return result, &ExecError{Kind: ExecKindTimeout, Err: fmt.Errorf("timeout reached, wasm exec for action '%s' cancelled", args.Name)}
+	}
+
+	if err != nil {
+		result.Output = truncateOutput(strings.Trim(stderr.String(), " \n"), args.MaxOutputBytes)
+		return result, &ExecError{Kind: ExecKindExited, Err: err}
+	}
+
+	result.Status = 0
+	result.Output = truncateOutput(strings.Trim(stdout.String(), " \n"), args.MaxOutputBytes)
+
+	bot.Log.Info().Msgf("wasm process finished for action '%s'", args.Name)
+
+	return result, nil
+}
@@ -0,0 +1,96 @@
+package logging
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog"
+	"github.com/target/flottbot/models"
+)
+
+// Config mirrors the `logging:` block in bot.yml. Level sets the
+// minimum level for the default stdout sink; File and Slack add a
+// rotating file and/or a Slack ops-channel sink on top of it.
+type Config struct {
+	Level string       `yaml:"level"`
+	File  *FileConfig  `yaml:"file"`
+	Slack *SlackConfig `yaml:"slack"`
+}
+
+// FileConfig is the yaml-facing counterpart to FileSinkConfig; MinLevel
+// is a string here since bot.yml has no native zerolog.Level type.
+type FileConfig struct {
+	Path       string `yaml:"path"`
+	MaxSizeMB  int    `yaml:"max_size_mb"`
+	MaxBackups int    `yaml:"max_backups"`
+	MaxAgeDays int    `yaml:"max_age_days"`
+	MinLevel   string `yaml:"min_level"`
+}
+
+// SlackConfig configures the Slack ops-channel sink.
+type SlackConfig struct {
+	Token    string `yaml:"token"`
+	Channel  string `yaml:"channel"`
+	MinLevel string `yaml:"min_level"`
+}
+
+// Configure builds the sinks declared in cfg and attaches them to
+// bot.Log via MultiLevelWriter, so every configured sink receives every
+// event bot.Log is given from this point on that is at or above the
+// sink's own minimum level. A zero Config leaves bot.Log's output
+// untouched.
+func Configure(bot *models.Bot, cfg Config) error {
+	if cfg == (Config{}) {
+		return nil
+	}
+
+	level := zerolog.InfoLevel
+
+	if cfg.Level != "" {
+		parsed, err := zerolog.ParseLevel(cfg.Level)
+		if err != nil {
+			return fmt.Errorf("invalid logging level '%s': %w", cfg.Level, err)
+		}
+
+		level = parsed
+	}
+
+	sinks := []Sink{NewStdoutSink(level)}
+
+	if cfg.File != nil {
+		fileLevel, err := parseLevelOrDefault(cfg.File.MinLevel, level)
+		if err != nil {
+			return fmt.Errorf("invalid file sink level: %w", err)
+		}
+
+		sinks = append(sinks, NewFileSink(FileSinkConfig{
+			Path:       cfg.File.Path,
+			MaxSizeMB:  cfg.File.MaxSizeMB,
+			MaxBackups: cfg.File.MaxBackups,
+			MaxAgeDays: cfg.File.MaxAgeDays,
+			MinLevel:   fileLevel,
+		}))
+	}
+
+	if cfg.Slack != nil {
+		slackLevel, err := parseLevelOrDefault(cfg.Slack.MinLevel, zerolog.WarnLevel)
+		if err != nil {
+			return fmt.Errorf("invalid slack sink level: %w", err)
+		}
+
+		sinks = append(sinks, NewSlackSink(cfg.Slack.Token, cfg.Slack.Channel, slackLevel))
+	}
+
+	bot.Log = bot.Log.Output(MultiLevelWriter(sinks...))
+
+	return nil
+}
+
+// parseLevelOrDefault parses s as a zerolog.Level, falling back to def
+// when s is empty.
+func parseLevelOrDefault(s string, def zerolog.Level) (zerolog.Level, error) {
+	if s == "" {
+		return def, nil
+	}
+
+	return zerolog.ParseLevel(s)
+}
@@ -0,0 +1,33 @@
+package logging
+
+import (
+	"github.com/rs/zerolog"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// FileSinkConfig configures the rotating file sink. MaxSizeMB,
+// MaxBackups, and MaxAgeDays mirror lumberjack's own fields and fall
+// back to lumberjack's zero-value behavior when unset: rotate at 100 MB,
+// keep every old file, and never remove files by age.
+type FileSinkConfig struct {
+	Path       string
+	MaxSizeMB  int
+	MaxBackups int
+	MaxAgeDays int
+	MinLevel   zerolog.Level
+}
+
+// NewFileSink returns a Sink that writes newline-delimited JSON log
+// events to a rotating file on disk.
+func NewFileSink(cfg FileSinkConfig) Sink {
+	return Sink{
+		Name:     "file",
+		MinLevel: cfg.MinLevel,
+		Writer: &lumberjack.Logger{
+			Filename:   cfg.Path,
+			MaxSize:    cfg.MaxSizeMB,
+			MaxBackups: cfg.MaxBackups,
+			MaxAge:     cfg.MaxAgeDays,
+		},
+	}
+}
@@ -0,0 +1,51 @@
+// Package logging provides the pluggable zerolog sinks configured under
+// the `logging:` block in bot.yml. Each sink gets its own minimum level
+// so, for example, a Slack sink can be limited to warn/error while the
+// file sink keeps everything.
+package logging
+
+import (
+	"io"
+
+	"github.com/rs/zerolog"
+)
+
+// Sink is a single logging destination with its own minimum level.
+type Sink struct {
+	Name     string
+	MinLevel zerolog.Level
+	Writer   io.Writer
+}
+
+// LevelWriter wraps a Sink's io.Writer so zerolog only forwards it
+// events at or above the sink's configured MinLevel.
+type LevelWriter struct {
+	Sink Sink
+}
+
+// Write satisfies io.Writer for callers that don't care about level
+// filtering; it always forwards to the underlying sink.
+func (w LevelWriter) Write(p []byte) (int, error) {
+	return w.Sink.Writer.Write(p)
+}
+
+// WriteLevel satisfies zerolog.LevelWriter, dropping events below the
+// sink's configured MinLevel instead of forwarding them.
+func (w LevelWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	if level < w.Sink.MinLevel {
+		return len(p), nil
+	}
+
+	return w.Sink.Writer.Write(p)
+}
+
+// MultiLevelWriter combines sinks into a single zerolog.LevelWriter, the
+// way bot.Log is configured from the `logging:` block in bot.yml.
+func MultiLevelWriter(sinks ...Sink) zerolog.LevelWriter {
+	writers := make([]io.Writer, len(sinks))
+	for i, s := range sinks {
+		writers[i] = LevelWriter{Sink: s}
+	}
+
+	return zerolog.MultiLevelWriter(writers...)
+}
@@ -0,0 +1,53 @@
+package logging
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestLevelWriterWriteLevel(t *testing.T) {
+	var buf bytes.Buffer
+	w := LevelWriter{Sink: Sink{Name: "test", MinLevel: zerolog.WarnLevel, Writer: &buf}}
+
+	n, err := w.WriteLevel(zerolog.DebugLevel, []byte("debug line"))
+	if err != nil {
+		t.Fatalf("WriteLevel(debug) returned error: %v", err)
+	}
+	if n != len("debug line") {
+		t.Errorf("WriteLevel(debug) returned n = %d, want %d", n, len("debug line"))
+	}
+	if buf.Len() != 0 {
+		t.Errorf("WriteLevel(debug) wrote %q below MinLevel, want nothing written", buf.String())
+	}
+
+	if _, err := w.WriteLevel(zerolog.ErrorLevel, []byte("error line")); err != nil {
+		t.Fatalf("WriteLevel(error) returned error: %v", err)
+	}
+	if buf.String() != "error line" {
+		t.Errorf("WriteLevel(error) = %q, want %q forwarded", buf.String(), "error line")
+	}
+}
+
+func TestSeverityColor(t *testing.T) {
+	tests := []struct {
+		level string
+		want  string
+	}{
+		{zerolog.WarnLevel.String(), "warning"},
+		{zerolog.ErrorLevel.String(), "danger"},
+		{zerolog.FatalLevel.String(), "danger"},
+		{zerolog.PanicLevel.String(), "danger"},
+	}
+
+	for _, tt := range tests {
+		if got := severityColor[tt.level]; got != tt.want {
+			t.Errorf("severityColor[%q] = %q, want %q", tt.level, got, tt.want)
+		}
+	}
+
+	if _, ok := severityColor[zerolog.InfoLevel.String()]; ok {
+		t.Errorf("severityColor should have no entry for info level")
+	}
+}
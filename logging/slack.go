@@ -0,0 +1,111 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/rs/zerolog"
+	"github.com/slack-go/slack"
+)
+
+// severityColor maps a zerolog level to the Slack attachment color used
+// for the ops channel sink, so warnings and errors are visually
+// distinct at a glance.
+var severityColor = map[string]string{
+	zerolog.WarnLevel.String():  "warning",
+	zerolog.ErrorLevel.String(): "danger",
+	zerolog.FatalLevel.String(): "danger",
+	zerolog.PanicLevel.String(): "danger",
+}
+
+// slackLogEvent is the subset of a zerolog JSON event the Slack sink
+// cares about.
+type slackLogEvent struct {
+	Level         string `json:"level"`
+	Message       string `json:"message"`
+	CorrelationID string `json:"correlation_id"`
+}
+
+// slackSinkQueueSize bounds how many log events can be waiting for
+// delivery to the ops channel at once, so a burst of warnings/errors
+// can't spawn unbounded concurrent chat.postMessage calls.
+const slackSinkQueueSize = 256
+
+// SlackSinkWriter posts zerolog events as color-coded attachments to an
+// ops channel, one at a time off a bounded internal queue, so events
+// post in the order they were logged instead of racing each other. It's
+// intended to be wrapped in a Sink with MinLevel set to at least warn,
+// since it's meant for paging humans, not archiving.
+type SlackSinkWriter struct {
+	api     *slack.Client
+	channel string
+	queue   chan slackLogEvent
+}
+
+// NewSlackSink returns a Sink that posts errors/warnings as
+// color-coded attachments to the given ops channel.
+func NewSlackSink(token, channel string, minLevel zerolog.Level) Sink {
+	w := SlackSinkWriter{
+		api:     slack.New(token),
+		channel: channel,
+		queue:   make(chan slackLogEvent, slackSinkQueueSize),
+	}
+
+	go w.run()
+
+	return Sink{Name: "slack", MinLevel: minLevel, Writer: w}
+}
+
+// run drains the queue and posts events to the ops channel one at a
+// time, for as long as the process runs.
+func (w SlackSinkWriter) run() {
+	for event := range w.queue {
+		color, ok := severityColor[event.Level]
+		if !ok {
+			color = "#cccccc"
+		}
+
+		text := event.Message
+		if event.CorrelationID != "" {
+			text = fmt.Sprintf("%s (correlation_id: %s)", text, event.CorrelationID)
+		}
+
+		_, _, err := w.api.PostMessage(
+			w.channel,
+			slack.MsgOptionAttachments(slack.Attachment{
+				Color: color,
+				Title: event.Level,
+				Text:  text,
+			}),
+		)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "logging: slack sink: could not post message: %v\n", err)
+		}
+	}
+}
+
+// Write parses the zerolog JSON event and enqueues it for delivery to
+// the ops channel; chat.postMessage itself happens on run's goroutine,
+// off zerolog's hot path. If the queue is already full - the channel
+// can't keep up with a burst - the event is dropped and noted on
+// stderr rather than blocking the logger or growing unbounded. A
+// failure to post is likewise written straight to stderr rather than
+// logged, so a broken ops channel can't turn into a log line that
+// re-enters this same sink forever.
+func (w SlackSinkWriter) Write(p []byte) (int, error) {
+	var event slackLogEvent
+
+	if err := json.Unmarshal(p, &event); err != nil {
+		// don't block logging on a malformed/unexpected event shape
+		return len(p), nil
+	}
+
+	select {
+	case w.queue <- event:
+	default:
+		fmt.Fprintf(os.Stderr, "logging: slack sink: queue full, dropping event: %s\n", event.Message)
+	}
+
+	return len(p), nil
+}
@@ -0,0 +1,17 @@
+package logging
+
+import (
+	"os"
+
+	"github.com/rs/zerolog"
+)
+
+// NewStdoutSink returns a Sink that writes structured JSON log events to
+// stdout, the same format zerolog produces by default.
+func NewStdoutSink(minLevel zerolog.Level) Sink {
+	return Sink{
+		Name:     "stdout",
+		MinLevel: minLevel,
+		Writer:   os.Stdout,
+	}
+}
@@ -0,0 +1,189 @@
+// Package cli implements an interactive terminal UI that emulates a
+// chat workspace, so rule authors can develop and test bots completely
+// offline without a real Slack workspace or token. It backs bot.CLI,
+// growing what used to be a plain stdin/stdout prompt into a left-hand
+// pane of simulated channels/DMs, a main pane of message history with
+// reactions rendered inline, and a compose box.
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/target/flottbot/models"
+	"github.com/target/flottbot/remote"
+)
+
+// Client implements the remote.Remote interface as an offline terminal
+// UI instead of a real chat backend.
+type Client struct {
+	Rooms []string // simulated channel/DM names, e.g. "#general", "@alice"
+
+	app      *tview.Application
+	rooms    *tview.List
+	history  *tview.TextView
+	compose  *tview.InputField
+	selected string
+}
+
+// validate that Client adheres to remote interface
+var _ remote.Remote = (*Client)(nil)
+
+// defaultRooms seeds the room list when the bot config doesn't declare
+// any, so the TUI always has at least a channel and a DM to select.
+var defaultRooms = []string{"#general", "@you"}
+
+// Read builds and runs the TUI. Each line submitted in the compose box
+// becomes a models.Message tagged with the Type that matches the
+// currently selected room (MsgTypeDirect for a "@name" room,
+// MsgTypeChannel/MsgTypePrivateChannel otherwise) and is pushed to
+// inputMsgs the same way a real Read implementation would.
+func (c *Client) Read(inputMsgs chan<- models.Message, rules map[string]models.Rule, bot *models.Bot) {
+	rooms := c.Rooms
+	if len(rooms) == 0 {
+		rooms = defaultRooms
+	}
+
+	c.app = tview.NewApplication()
+	c.selected = rooms[0]
+
+	c.rooms = tview.NewList().ShowSecondaryText(false)
+	for _, room := range rooms {
+		c.rooms.AddItem(room, "", 0, nil)
+	}
+
+	c.rooms.SetChangedFunc(func(_ int, room string, _ string, _ rune) {
+		c.selected = room
+	})
+	c.rooms.SetBorder(true).SetTitle("Channels/DMs")
+
+	c.history = tview.NewTextView().SetDynamicColors(true).SetChangedFunc(func() { c.app.Draw() })
+	c.history.SetBorder(true).SetTitle("Messages")
+
+	c.compose = tview.NewInputField().SetLabel("> ")
+	c.compose.SetBorder(true).SetTitle("Compose")
+	c.compose.SetDoneFunc(func(key tcell.Key) {
+		if key != tcell.KeyEnter {
+			return
+		}
+
+		text := c.compose.GetText()
+		if text == "" {
+			return
+		}
+
+		msg := models.NewMessage()
+		msg.ChannelID = c.selected
+		msg.Output = text
+		msg.Type = roomMessageType(c.selected)
+
+		fmt.Fprintf(c.history, "[%s] %s\n", c.selected, text)
+
+		inputMsgs <- msg
+
+		c.compose.SetText("")
+	})
+
+	main := tview.NewFlex().
+		AddItem(c.rooms, 24, 1, true).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(c.history, 0, 1, false).
+			AddItem(c.compose, 3, 1, false), 0, 4, false)
+
+	c.app.SetRoot(main, true).SetFocus(c.compose)
+
+	if err := c.app.Run(); err != nil {
+		bot.Log.Error().Msgf("cli ui exited: %v", err)
+	}
+}
+
+// roomMessageType maps a simulated room name to the models.MessageType a
+// real remote would assign: "@name" rooms are direct messages, a name
+// prefixed with "#" is a public channel, anything else a private one.
+func roomMessageType(room string) models.MessageType {
+	switch {
+	case strings.HasPrefix(room, "@"):
+		return models.MsgTypeDirect
+	case strings.HasPrefix(room, "#"):
+		return models.MsgTypeChannel
+	default:
+		return models.MsgTypePrivateChannel
+	}
+}
+
+// Send renders the message into the shared history pane, prefixed with
+// the room it targets.
+func (c *Client) Send(message models.Message, bot *models.Bot) {
+	if c.app == nil {
+		return
+	}
+
+	c.app.QueueUpdateDraw(func() {
+		fmt.Fprintf(c.history, "[%s] bot: %s\n", message.ChannelID, message.Output)
+	})
+}
+
+// Reaction renders the reaction emoji inline after the most recent line
+// in the history pane, approximating how a real client shows a reaction
+// attached to a message.
+func (c *Client) Reaction(message models.Message, rule models.Rule, bot *models.Bot) {
+	if c.app == nil || rule.Reaction == "" {
+		return
+	}
+
+	c.app.QueueUpdateDraw(func() {
+		fmt.Fprintf(c.history, "    (reacted :%s:)\n", rule.Reaction)
+	})
+}
+
+// InteractiveComponents renders rule.Interactive's buttons as ASCII
+// buttons in a form that temporarily replaces the three-pane layout.
+// Selecting one resolves the mapped rule
+// name via the same button -> rule DSL the Slack remote uses and
+// injects a synthetic message so the mapped rule's actions run, letting
+// multi-step conversational flows be exercised entirely offline.
+func (c *Client) InteractiveComponents(inputMsgs chan<- models.Message, message *models.Message, rule models.Rule, bot *models.Bot) {
+	if c.app == nil || len(rule.Interactive.Buttons) == 0 {
+		return
+	}
+
+	form := tview.NewForm()
+
+	for actionID, ruleName := range rule.Interactive.Buttons {
+		actionID, ruleName := actionID, ruleName
+
+		form.AddButton(actionID, func() {
+			msg := models.NewMessage()
+			msg.ChannelID = c.selected
+			msg.Type = roomMessageType(c.selected)
+			msg.Vars = map[string]string{"_button_rule": ruleName}
+
+			fmt.Fprintf(c.history, "[%s] you pressed: %s\n", c.selected, actionID)
+
+			// off the tview event-loop goroutine, so a stalled inputMsgs
+			// consumer can't block the UI from redrawing.
+			go func() { inputMsgs <- msg }()
+
+			c.app.SetRoot(c.rootFlex(), true).SetFocus(c.compose)
+		})
+	}
+
+	form.SetBorder(true).SetTitle(rule.Name)
+
+	c.app.QueueUpdateDraw(func() {
+		c.app.SetRoot(form, true).SetFocus(form)
+	})
+}
+
+// rootFlex rebuilds the normal three-pane layout, used to restore it
+// after an interactive button form is dismissed.
+func (c *Client) rootFlex() *tview.Flex {
+	return tview.NewFlex().
+		AddItem(c.rooms, 24, 1, true).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(c.history, 0, 1, false).
+			AddItem(c.compose, 3, 1, false), 0, 4, false)
+}
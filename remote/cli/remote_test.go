@@ -0,0 +1,24 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/target/flottbot/models"
+)
+
+func TestRoomMessageType(t *testing.T) {
+	tests := []struct {
+		room string
+		want models.MessageType
+	}{
+		{"@you", models.MsgTypeDirect},
+		{"#general", models.MsgTypeChannel},
+		{"ops-private", models.MsgTypePrivateChannel},
+	}
+
+	for _, tt := range tests {
+		if got := roomMessageType(tt.room); got != tt.want {
+			t.Errorf("roomMessageType(%q) = %v, want %v", tt.room, got, tt.want)
+		}
+	}
+}
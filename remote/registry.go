@@ -0,0 +1,178 @@
+package remote
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/target/flottbot/models"
+)
+
+// Bridge pairs a room/channel on one remote with the equivalent room on
+// one or more other remotes. A message posted into any room listed in
+// Rooms is re-emitted, with an author prefix, into the other rooms -
+// the same mapping concept matterbridge calls a "gateway".
+type Bridge struct {
+	Rooms map[string]string // RemoteID -> room/channel ID for this bridge
+}
+
+// maxInFlightMirrors bounds how many mirrored Sends can be running at
+// once across all bridges, so a burst of bridged traffic into a slow
+// remote can't spawn an unbounded number of concurrent goroutines.
+const maxInFlightMirrors = 64
+
+// Registry multiplexes the Remote interface across N concurrently
+// configured chat backends (Slack, Discord, Telegram, Mattermost, IRC),
+// so a single rule can fire across all of them and flottbot can act as
+// a chat bridge in addition to a single-platform bot framework.
+type Registry struct {
+	remotes map[string]Remote
+	bridges []Bridge
+
+	mirrorSem chan struct{}
+}
+
+// validate that Registry adheres to the Remote interface, so it can be
+// used anywhere a single Remote is expected (e.g. nested inside another
+// bridge).
+var _ Remote = (*Registry)(nil)
+
+// NewRegistry returns an empty Registry ready to have remotes registered
+// with it.
+func NewRegistry() *Registry {
+	return &Registry{
+		remotes:   map[string]Remote{},
+		mirrorSem: make(chan struct{}, maxInFlightMirrors),
+	}
+}
+
+// Register adds a configured Remote under id (e.g. "slack", "discord"),
+// the name rules use in their `remotes:` field to target it.
+func (r *Registry) Register(id string, rem Remote) {
+	r.remotes[id] = rem
+}
+
+// AddBridge registers a room mapping to mirror messages across.
+func (r *Registry) AddBridge(b Bridge) {
+	r.bridges = append(r.bridges, b)
+}
+
+// targets resolves a rule's `remotes:` field ("all", or an explicit
+// list of registered remote IDs) to the concrete Remote clients a rule
+// action should fan out to.
+func (r *Registry) targets(names []string) map[string]Remote {
+	if len(names) == 1 && strings.EqualFold(names[0], "all") {
+		return r.remotes
+	}
+
+	targets := make(map[string]Remote, len(names))
+
+	for _, name := range names {
+		if rem, ok := r.remotes[name]; ok {
+			targets[name] = rem
+		}
+	}
+
+	return targets
+}
+
+// Read starts a Read loop against every registered remote. Each message
+// is tagged with its origin RemoteID before being passed along to
+// inputMsgs, and also handed to the bridge fan-out so rooms mapped via
+// AddBridge stay in sync across remotes.
+func (r *Registry) Read(inputMsgs chan<- models.Message, rules map[string]models.Rule, bot *models.Bot) {
+	// Buffered so a burst of bridged traffic doesn't immediately stall a
+	// remote's read loop on intake; mirror itself fans each message's
+	// Sends out concurrently so a single slow backend can't block the
+	// rest regardless.
+	bridged := make(chan models.Message, 64)
+
+	for id, rem := range r.remotes {
+		id, rem := id, rem
+
+		go func() {
+			local := make(chan models.Message)
+			go rem.Read(local, rules, bot)
+
+			for msg := range local {
+				msg.RemoteID = id
+				inputMsgs <- msg
+				bridged <- msg
+			}
+		}()
+	}
+
+	go r.mirror(bridged, bot)
+}
+
+// mirror re-emits a bridged message, with an author prefix, into every
+// other room paired with its origin room across the registered bridges.
+// Each target's Send runs in its own goroutine, bounded by mirrorSem, so
+// one slow remote can't stall mirroring - or message intake upstream in
+// Read - for the rest; once maxInFlightMirrors Sends are already in
+// flight, further mirrored Sends for that burst are dropped and logged
+// rather than growing goroutines unbounded.
+func (r *Registry) mirror(messages <-chan models.Message, bot *models.Bot) {
+	for msg := range messages {
+		for _, bridge := range r.bridges {
+			room, ok := bridge.Rooms[msg.RemoteID]
+			if !ok || room != msg.ChannelID {
+				continue
+			}
+
+			for remoteID, room := range bridge.Rooms {
+				if remoteID == msg.RemoteID {
+					continue
+				}
+
+				rem, ok := r.remotes[remoteID]
+				if !ok {
+					continue
+				}
+
+				mirrored := msg
+				mirrored.RemoteID = remoteID
+				mirrored.ChannelID = room
+				mirrored.Output = fmt.Sprintf("[%s] %s", msg.Username, msg.Output)
+
+				select {
+				case r.mirrorSem <- struct{}{}:
+					go func() {
+						defer func() { <-r.mirrorSem }()
+						rem.Send(mirrored, bot)
+					}()
+				default:
+					bot.Log.Warn().Msgf("dropping mirrored message to remote '%s': too many in-flight mirror sends", remoteID)
+				}
+			}
+		}
+	}
+}
+
+// Send implements the Remote interface's Send(message, bot) signature,
+// fanning message out to every remote named in message.Remotes (or all
+// registered remotes for `remotes: all`) - the same targets a rule's
+// Reaction/InteractiveComponents resolve from rule.Remotes, carried on
+// the message itself here since Send takes no rule.
+func (r *Registry) Send(message models.Message, bot *models.Bot) {
+	for id, rem := range r.targets(message.Remotes) {
+		out := message
+		out.RemoteID = id
+
+		rem.Send(out, bot)
+	}
+}
+
+// Reaction fans the reaction out to every targeted remote.
+func (r *Registry) Reaction(message models.Message, rule models.Rule, bot *models.Bot) {
+	for _, rem := range r.targets(rule.Remotes) {
+		rem.Reaction(message, rule, bot)
+	}
+}
+
+// InteractiveComponents fans the interactive setup out to every targeted
+// remote.
+func (r *Registry) InteractiveComponents(inputMsgs chan<- models.Message, message *models.Message, rule models.Rule, bot *models.Bot) {
+	for _, rem := range r.targets(rule.Remotes) {
+		rem.InteractiveComponents(inputMsgs, message, rule, bot)
+	}
+}
@@ -0,0 +1,51 @@
+package remote
+
+import (
+	"testing"
+
+	"github.com/target/flottbot/models"
+)
+
+type fakeRemote struct{}
+
+func (fakeRemote) Read(inputMsgs chan<- models.Message, rules map[string]models.Rule, bot *models.Bot) {
+}
+func (fakeRemote) Send(message models.Message, bot *models.Bot)                       {}
+func (fakeRemote) Reaction(message models.Message, rule models.Rule, bot *models.Bot) {}
+func (fakeRemote) InteractiveComponents(inputMsgs chan<- models.Message, message *models.Message, rule models.Rule, bot *models.Bot) {
+}
+
+func newTestRegistry() *Registry {
+	r := NewRegistry()
+	r.Register("slack", fakeRemote{})
+	r.Register("discord", fakeRemote{})
+
+	return r
+}
+
+func TestRegistryTargetsAll(t *testing.T) {
+	r := newTestRegistry()
+
+	targets := r.targets([]string{"all"})
+	if len(targets) != 2 {
+		t.Fatalf("targets([\"all\"]) = %v, want both registered remotes", targets)
+	}
+}
+
+func TestRegistryTargetsExplicitList(t *testing.T) {
+	r := newTestRegistry()
+
+	targets := r.targets([]string{"slack"})
+	if _, ok := targets["slack"]; !ok || len(targets) != 1 {
+		t.Fatalf("targets([\"slack\"]) = %v, want only 'slack'", targets)
+	}
+}
+
+func TestRegistryTargetsUnknownNameIgnored(t *testing.T) {
+	r := newTestRegistry()
+
+	targets := r.targets([]string{"nonexistent"})
+	if len(targets) != 0 {
+		t.Fatalf("targets([\"nonexistent\"]) = %v, want none", targets)
+	}
+}
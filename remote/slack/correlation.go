@@ -0,0 +1,25 @@
+package slack
+
+import (
+	"github.com/google/uuid"
+	"github.com/target/flottbot/models"
+)
+
+// relayWithCorrelationID forwards messages from in to out, stamping a
+// fresh CorrelationID onto any message that doesn't already have one
+// (e.g. a message re-emitted by a bridge that wants to keep the
+// originating ID). The ID is logged here and is carried on the message
+// itself from this point on, so ScriptExec, Send, and
+// InteractiveComponents can all log it without needing it passed
+// separately.
+func relayWithCorrelationID(in <-chan models.Message, out chan<- models.Message, bot *models.Bot) {
+	for msg := range in {
+		if msg.CorrelationID == "" {
+			msg.CorrelationID = uuid.NewString()
+		}
+
+		bot.Log.Debug().Str("correlation_id", msg.CorrelationID).Msgf("received message '%s'", msg.ID)
+
+		out <- msg
+	}
+}
@@ -0,0 +1,212 @@
+package slack
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/slack-go/slack"
+	"github.com/target/flottbot/models"
+)
+
+// viewTarget is what a tracked view_id/callback_id resolves back to: the
+// rule that opened it and the channel it was opened from, so a later
+// view_submission can both resume the right rule and reply in the right
+// place.
+type viewTarget struct {
+	rule    string
+	channel string
+}
+
+// openViews correlates an in-flight modal's view_id/callback_id back to
+// the rule that opened it, so that when Slack posts the view_submission
+// payload we know which rule's follow-up actions to run and which
+// msg.Vars to populate from the submitted fields.
+var openViews = struct {
+	mu    sync.Mutex
+	byID  map[string]viewTarget // view_id -> target
+	cbIDs map[string]viewTarget // callback_id -> target
+}{
+	byID:  map[string]viewTarget{},
+	cbIDs: map[string]viewTarget{},
+}
+
+// trackView records that viewID/callbackID belong to ruleName and were
+// opened from channelID, so a later view_submission can be routed back
+// to both.
+func trackView(viewID, callbackID, ruleName, channelID string) {
+	openViews.mu.Lock()
+	defer openViews.mu.Unlock()
+
+	target := viewTarget{rule: ruleName, channel: channelID}
+	openViews.byID[viewID] = target
+	openViews.cbIDs[callbackID] = target
+}
+
+// ruleForView returns the rule name and originating channel a
+// view_id/callback_id was opened for, if any.
+func ruleForView(viewID, callbackID string) (viewTarget, bool) {
+	openViews.mu.Lock()
+	defer openViews.mu.Unlock()
+
+	if target, ok := openViews.byID[viewID]; ok {
+		return target, true
+	}
+
+	target, ok := openViews.cbIDs[callbackID]
+
+	return target, ok
+}
+
+// untrackView forgets a view_id/callback_id pair once its submission has
+// been resolved, so openViews doesn't grow for the life of the process.
+func untrackView(viewID, callbackID string) {
+	openViews.mu.Lock()
+	defer openViews.mu.Unlock()
+
+	delete(openViews.byID, viewID)
+	delete(openViews.cbIDs, callbackID)
+}
+
+// OpenInteractiveRule opens rule.Interactive's modal in response to the
+// rule being triggered (e.g. from a slash command or message match).
+// handleShortcut calls it for a shortcut-triggered rule whose
+// Interactive block declares a View. channelID is the room the rule
+// was triggered from, if known, so a later view_submission can reply
+// there.
+func (c *Client) OpenInteractiveRule(triggerID, channelID string, rule models.Rule, bot *models.Bot) {
+	if rule.Interactive.View == nil {
+		return
+	}
+
+	api := c.new()
+
+	if err := openModal(api, triggerID, channelID, rule); err != nil {
+		bot.Log.Error().Msgf("could not open interactive modal: %v", err)
+		return
+	}
+
+	bot.Log.Info().Msgf("opened interactive modal for rule '%s'", rule.Name)
+}
+
+// openModal opens rule.Interactive's modal view via views.open, tracking
+// the returned view (and its originating channel) so a subsequent
+// submission can be correlated back to this rule.
+func openModal(api *slack.Client, triggerID, channelID string, rule models.Rule) error {
+	if rule.Interactive.View == nil {
+		return fmt.Errorf("rule '%s' has no interactive view to open", rule.Name)
+	}
+
+	view, err := api.OpenView(triggerID, *rule.Interactive.View)
+	if err != nil {
+		return fmt.Errorf("could not open view for rule '%s': %w", rule.Name, err)
+	}
+
+	trackView(view.ID, view.CallbackID, rule.Name, channelID)
+
+	return nil
+}
+
+// valuesFromSubmission flattens a view_submission's block state (keyed
+// by block ID then action ID) into a flat map of submitted values,
+// ready to be merged into msg.Vars so follow-up actions can reference
+// them the same way they reference any other templated variable.
+func valuesFromSubmission(state slack.ViewState) map[string]string {
+	vars := make(map[string]string)
+
+	for _, block := range state.Values {
+		for actionID, value := range block {
+			switch {
+			case value.SelectedOption.Value != "":
+				vars[actionID] = value.SelectedOption.Value
+			case value.SelectedDate != "":
+				vars[actionID] = value.SelectedDate
+			case value.Value != "":
+				vars[actionID] = value.Value
+			}
+		}
+	}
+
+	return vars
+}
+
+// ruleForButtonAction finds the rule that should fire when the given
+// block-kit button (identified by its action ID) is clicked, scanning
+// every rule's button -> rule name mapping declared in
+// rule.Interactive.Buttons. This is what lets an Interactive rule behave
+// like an inline keyboard driving a multi-step conversational flow
+// without writing Go. Unlike view_submission/OpenInteractiveRule's
+// modal flow, a message posted with buttons (chat.postMessage with
+// blocks, rather than views.open) carries no view to correlate the
+// click back to a single rule, so every rule's mapping is searched
+// instead of just the one a tracked view would have resolved to.
+func ruleForButtonAction(rules map[string]models.Rule, actionID string) (string, bool) {
+	for _, rule := range rules {
+		if name, ok := rule.Interactive.Buttons[actionID]; ok {
+			return name, true
+		}
+	}
+
+	return "", false
+}
+
+// handleViewSubmission resolves a view_submission InteractionCallback
+// back to the rule that opened it, merges the submitted field values
+// into msg.Vars, and hands it off to inputMsgs so the rule's follow-up
+// actions can run with the submitted values available.
+func handleViewSubmission(callback slack.InteractionCallback, rules map[string]models.Rule, inputMsgs chan<- models.Message, bot *models.Bot) {
+	target, ok := ruleForView(callback.View.ID, callback.View.CallbackID)
+	if !ok {
+		bot.Log.Warn().Msgf("received view_submission for untracked view '%s'", callback.View.ID)
+		return
+	}
+
+	rule, ok := rules[target.rule]
+	if !ok {
+		bot.Log.Warn().Msgf("received view_submission for unknown rule '%s'", target.rule)
+		return
+	}
+
+	msg := models.NewMessage()
+	msg.Type = models.MsgTypeChannel
+	msg.ChannelID = target.channel
+	msg.Vars = valuesFromSubmission(callback.View.State)
+	msg.Vars["_rule"] = rule.Name
+
+	untrackView(callback.View.ID, callback.View.CallbackID)
+
+	bot.Log.Info().Msgf("resuming rule '%s' with submitted modal values", rule.Name)
+
+	inputMsgs <- msg
+}
+
+// handleBlockActions resolves a block_actions InteractionCallback's
+// clicked button to its mapped rule via rule.Interactive.Buttons (the
+// same button -> rule DSL the CLI remote emulates) and hands it off to
+// inputMsgs so that rule's actions run. Buttons fired from a posted
+// message's "inline keyboard" - rather than a modal opened via
+// views.open - carry no View.ID/CallbackID to look up, so the rule is
+// resolved by scanning every rule's button mapping and the reply
+// channel comes from the message's own container, not a tracked view.
+func handleBlockActions(callback slack.InteractionCallback, rules map[string]models.Rule, inputMsgs chan<- models.Message, bot *models.Bot) {
+	if len(callback.ActionCallback.BlockActions) == 0 {
+		bot.Log.Warn().Msg("received block_actions with no actions")
+		return
+	}
+
+	actionID := callback.ActionCallback.BlockActions[0].ActionID
+
+	ruleName, ok := ruleForButtonAction(rules, actionID)
+	if !ok {
+		bot.Log.Warn().Msgf("received block_actions for unmapped button '%s'", actionID)
+		return
+	}
+
+	msg := models.NewMessage()
+	msg.Type = models.MsgTypeChannel
+	msg.ChannelID = callback.Channel.ID
+	msg.Vars = map[string]string{"_button_rule": ruleName}
+
+	bot.Log.Info().Msgf("dispatching rule '%s' for button '%s'", ruleName, actionID)
+
+	inputMsgs <- msg
+}
@@ -0,0 +1,41 @@
+package slack
+
+import (
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+func TestValuesFromSubmission(t *testing.T) {
+	state := slack.ViewState{
+		Values: map[string]map[string]slack.BlockAction{
+			"block_text": {
+				"action_text": {Value: "hello"},
+			},
+			"block_select": {
+				"action_select": {SelectedOption: slack.OptionBlockObject{Value: "option_a"}},
+			},
+			"block_date": {
+				"action_date": {SelectedDate: "2026-07-27"},
+			},
+		},
+	}
+
+	got := valuesFromSubmission(state)
+
+	want := map[string]string{
+		"action_text":   "hello",
+		"action_select": "option_a",
+		"action_date":   "2026-07-27",
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("valuesFromSubmission() = %v, want %v", got, want)
+	}
+
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("valuesFromSubmission()[%q] = %q, want %q", k, got[k], v)
+		}
+	}
+}
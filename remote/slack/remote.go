@@ -45,7 +45,7 @@ func (c *Client) Reaction(message models.Message, rule models.Rule, bot *models.
 			bot.Log.Error().Msgf("could not add reaction: %v", err)
 			return
 		}
-		bot.Log.Info().Msgf("removed reaction '%s' for rule '%s'", rule.RemoveReaction, rule.Name)
+		bot.Log.Info().Str("correlation_id", message.CorrelationID).Msgf("removed reaction '%s' for rule '%s'", rule.RemoveReaction, rule.Name)
 	}
 	if rule.Reaction != "" {
 		// Init api client
@@ -57,7 +57,7 @@ func (c *Client) Reaction(message models.Message, rule models.Rule, bot *models.
 			bot.Log.Error().Msgf("could not add reaction: %v", err)
 			return
 		}
-		bot.Log.Info().Msgf("added reaction '%s' for rule '%s'", rule.Reaction, rule.Name)
+		bot.Log.Info().Str("correlation_id", message.CorrelationID).Msgf("added reaction '%s' for rule '%s'", rule.Reaction, rule.Name)
 	}
 }
 
@@ -81,6 +81,12 @@ func (c *Client) Read(inputMsgs chan<- models.Message, rules map[string]models.R
 	// set the bot ID
 	bot.ID = rat.UserID
 
+	// tag every message with a correlation ID as it leaves Read, so the
+	// same ID can be threaded through ScriptExec, Send, and
+	// InteractiveComponents for end-to-end log correlation
+	tagged := make(chan models.Message)
+	go relayWithCorrelationID(tagged, inputMsgs, bot)
+
 	if c.AppToken != "" {
 		// handle Socket Mode
 		// assuming Socket Mode if slack_app_token is provided
@@ -92,13 +98,11 @@ func (c *Client) Read(inputMsgs chan<- models.Message, rules map[string]models.R
 			slack.OptionLog(log.New(bot.Log, "", 0)),
 		)
 
-		// move the above inside readFromSocketMode below :o
-
-		readFromSocketMode(sm, inputMsgs, bot)
+		readFromSocketMode(sm, rules, tagged, bot)
 	} else if c.SigningSecret != "" {
 		// handle Events API setup
 		// assuming Events API setup if slack_signing_secret is provided
-		readFromEventsAPI(api, c.SigningSecret, inputMsgs, bot)
+		readFromEventsAPI(api, c.SigningSecret, tagged, bot)
 	}
 
 	// slack is not configured correctly and cli is set to false
@@ -110,7 +114,7 @@ func (c *Client) Read(inputMsgs chan<- models.Message, rules map[string]models.R
 
 // Send implementation to satisfy remote interface
 func (c *Client) Send(message models.Message, bot *models.Bot) {
-	bot.Log.Debug().Msgf("sending message '%s'", message.ID)
+	bot.Log.Debug().Str("correlation_id", message.CorrelationID).Msgf("sending message '%s'", message.ID)
 
 	api := c.new()
 
@@ -0,0 +1,300 @@
+package slack
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+	"github.com/slack-go/slack/socketmode"
+	"github.com/target/flottbot/models"
+)
+
+// ruleForSlashCommand finds the rule whose `slash_command:` matcher
+// matches the invoked command, e.g. "/deploy".
+func ruleForSlashCommand(rules map[string]models.Rule, command string) (models.Rule, bool) {
+	for _, rule := range rules {
+		if rule.SlashCommand == command {
+			return rule, true
+		}
+	}
+
+	return models.Rule{}, false
+}
+
+// ruleForShortcut finds the rule whose `shortcut:` matcher matches the
+// invoked global/message shortcut's callback ID.
+func ruleForShortcut(rules map[string]models.Rule, callbackID string) (models.Rule, bool) {
+	for _, rule := range rules {
+		if rule.Shortcut == callbackID {
+			return rule, true
+		}
+	}
+
+	return models.Rule{}, false
+}
+
+// parseCommandArgs parses a slash command's free-text arguments into
+// msg.Vars according to the rule's type-tagged Args declarations,
+// supporting both positional ("/deploy prod 3") and named
+// ("/deploy env=prod count=3") forms.
+func parseCommandArgs(text string, specs []models.ArgSpec) (map[string]string, error) {
+	vars := make(map[string]string, len(specs))
+	fields := strings.Fields(text)
+
+	named := make(map[string]string)
+	positional := make([]string, 0, len(fields))
+
+	for _, field := range fields {
+		if name, value, ok := strings.Cut(field, "="); ok {
+			named[name] = value
+		} else {
+			positional = append(positional, field)
+		}
+	}
+
+	positionalIdx := 0
+
+	for _, spec := range specs {
+		value, ok := named[spec.Name]
+		if !ok && positionalIdx < len(positional) {
+			value = positional[positionalIdx]
+			positionalIdx++
+			ok = true
+		}
+
+		if !ok {
+			if spec.Required {
+				return nil, fmt.Errorf("missing required argument '%s'", spec.Name)
+			}
+
+			continue
+		}
+
+		if err := validateArgType(spec, value); err != nil {
+			return nil, err
+		}
+
+		vars[spec.Name] = value
+	}
+
+	return vars, nil
+}
+
+// validateArgType confirms value parses as spec.Type, so a bad
+// "/deploy count=abc" fails fast with a useful error instead of
+// surfacing as a confusing downstream substitution failure.
+func validateArgType(spec models.ArgSpec, value string) error {
+	switch spec.Type {
+	case models.ArgTypeInt:
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("argument '%s' must be an int, got '%s'", spec.Name, value)
+		}
+	case models.ArgTypeFloat:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("argument '%s' must be a float, got '%s'", spec.Name, value)
+		}
+	case models.ArgTypeBool:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("argument '%s' must be a bool, got '%s'", spec.Name, value)
+		}
+	}
+
+	return nil
+}
+
+// ackResponseURL satisfies Slack's 3-second slash command ack
+// requirement: the rule's real action may take longer to run, so we
+// immediately post a text-less ephemeral response to response_url and
+// let the action post its actual result as a follow-up message once
+// it's done.
+func ackResponseURL(responseURL string) error {
+	body, _ := json.Marshal(map[string]string{"response_type": "ephemeral"})
+
+	resp, err := http.Post(responseURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("could not ack response_url: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// handleSlashCommand acks the command within Slack's 3-second window,
+// resolves the matching rule, parses its arguments into msg.Vars, and
+// hands the message off to inputMsgs so the rule's actions run
+// asynchronously and post their result via chat.postMessage.
+func handleSlashCommand(client *socketmode.Client, evt socketmode.Event, cmd slack.SlashCommand, rules map[string]models.Rule, inputMsgs chan<- models.Message, bot *models.Bot) {
+	client.Ack(*evt.Request)
+
+	if cmd.Command == "/help" {
+		_ = ackResponseURL(cmd.ResponseURL)
+
+		postHelp(cmd.ResponseURL, rules)
+
+		return
+	}
+
+	rule, ok := ruleForSlashCommand(rules, cmd.Command)
+	if !ok {
+		bot.Log.Warn().Msgf("received unknown slash command '%s'", cmd.Command)
+		return
+	}
+
+	if err := ackResponseURL(cmd.ResponseURL); err != nil {
+		bot.Log.Warn().Msgf("slash command '%s': %v", cmd.Command, err)
+	}
+
+	vars, err := parseCommandArgs(cmd.Text, rule.Args)
+	if err != nil {
+		bot.Log.Error().Msgf("could not parse args for slash command '%s': %v", cmd.Command, err)
+		return
+	}
+
+	msg := models.NewMessage()
+	msg.Type = models.MsgTypeChannel
+	msg.ChannelID = cmd.ChannelID
+	msg.Vars = vars
+	msg.Vars["_response_url"] = cmd.ResponseURL
+	msg.Vars["_trigger_id"] = cmd.TriggerID
+
+	inputMsgs <- msg
+}
+
+// handleShortcut resolves a global/message shortcut to its rule and
+// hands it off the same way handleSlashCommand does, without argument
+// parsing since shortcuts carry no free-text input.
+func handleShortcut(client *socketmode.Client, evt socketmode.Event, callback slack.InteractionCallback, rules map[string]models.Rule, inputMsgs chan<- models.Message, bot *models.Bot) {
+	client.Ack(*evt.Request)
+
+	rule, ok := ruleForShortcut(rules, callback.CallbackID)
+	if !ok {
+		bot.Log.Warn().Msgf("received unknown shortcut '%s'", callback.CallbackID)
+		return
+	}
+
+	msg := models.NewMessage()
+	msg.Type = models.MsgTypeChannel
+	msg.Vars = map[string]string{"_trigger_id": callback.TriggerID}
+
+	if rule.Interactive.View != nil {
+		client := &Client{Token: bot.SlackToken}
+		go client.OpenInteractiveRule(callback.TriggerID, callback.Channel.ID, rule, bot)
+
+		return
+	}
+
+	inputMsgs <- msg
+}
+
+// handleMessageEvent converts an Events API message (delivered over
+// Socket Mode as an EventTypeEventsAPI envelope) into a models.Message
+// and hands it off to inputMsgs, the same way a real-time message
+// arrives from the classic Events API reader, so message-matched rules
+// keep firing alongside the slash command/shortcut dispatch above. Bot
+// messages and subtyped events (edits, joins, etc.) are ignored so the
+// bot doesn't react to its own output or to non-content events.
+func handleMessageEvent(event *slackevents.MessageEvent, inputMsgs chan<- models.Message) {
+	if event.BotID != "" || event.SubType != "" {
+		return
+	}
+
+	msg := models.NewMessage()
+	msg.Type = models.MsgTypeChannel
+	msg.ChannelID = event.Channel
+	msg.Timestamp = event.TimeStamp
+	msg.Output = event.Text
+
+	inputMsgs <- msg
+}
+
+// readFromSocketMode runs the Socket Mode event loop: it starts a Socket
+// Mode client for api and dispatches slash commands, shortcuts, button
+// clicks, view submissions, and ordinary messages to their matching rule
+// as they arrive, leaving every other event type unhandled for now.
+func readFromSocketMode(api *slack.Client, rules map[string]models.Rule, inputMsgs chan<- models.Message, bot *models.Bot) {
+	client := socketmode.New(api)
+
+	go client.Run()
+
+	for evt := range client.Events {
+		switch evt.Type {
+		case socketmode.EventTypeSlashCommand:
+			cmd, ok := evt.Data.(slack.SlashCommand)
+			if !ok {
+				continue
+			}
+
+			handleSlashCommand(client, evt, cmd, rules, inputMsgs, bot)
+		case socketmode.EventTypeInteractive:
+			callback, ok := evt.Data.(slack.InteractionCallback)
+			if !ok {
+				continue
+			}
+
+			switch callback.Type {
+			case slack.InteractionTypeShortcut, slack.InteractionTypeMessageAction:
+				handleShortcut(client, evt, callback, rules, inputMsgs, bot)
+			case slack.InteractionTypeViewSubmission:
+				client.Ack(*evt.Request)
+				handleViewSubmission(callback, rules, inputMsgs, bot)
+			case slack.InteractionTypeBlockActions:
+				client.Ack(*evt.Request)
+				handleBlockActions(callback, rules, inputMsgs, bot)
+			}
+		case socketmode.EventTypeEventsAPI:
+			client.Ack(*evt.Request)
+
+			eventsAPIEvent, ok := evt.Data.(slackevents.EventsAPIEvent)
+			if !ok {
+				continue
+			}
+
+			if inner, ok := eventsAPIEvent.InnerEvent.Data.(*slackevents.MessageEvent); ok {
+				handleMessageEvent(inner, inputMsgs)
+			}
+		}
+	}
+}
+
+// postHelp builds usage text from every rule's slash command/shortcut
+// metadata and posts it as an ephemeral response to response_url,
+// backing the built-in `/help` command.
+func postHelp(responseURL string, rules map[string]models.Rule) {
+	var b strings.Builder
+
+	b.WriteString("Available commands:\n")
+
+	for _, rule := range rules {
+		if rule.SlashCommand == "" {
+			continue
+		}
+
+		b.WriteString(fmt.Sprintf("- `%s`", rule.SlashCommand))
+
+		for _, spec := range rule.Args {
+			if spec.Required {
+				b.WriteString(fmt.Sprintf(" <%s>", spec.Name))
+			} else {
+				b.WriteString(fmt.Sprintf(" [%s]", spec.Name))
+			}
+		}
+
+		b.WriteString("\n")
+	}
+
+	payload, _ := json.Marshal(map[string]string{
+		"response_type": "ephemeral",
+		"text":          b.String(),
+	})
+
+	resp, err := http.Post(responseURL, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+}
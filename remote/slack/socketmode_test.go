@@ -0,0 +1,97 @@
+package slack
+
+import (
+	"testing"
+
+	"github.com/target/flottbot/models"
+)
+
+func TestParseCommandArgsPositional(t *testing.T) {
+	specs := []models.ArgSpec{
+		{Name: "env", Required: true},
+		{Name: "count", Type: models.ArgTypeInt, Required: false},
+	}
+
+	vars, err := parseCommandArgs("prod 3", specs)
+	if err != nil {
+		t.Fatalf("parseCommandArgs() returned error: %v", err)
+	}
+
+	if vars["env"] != "prod" || vars["count"] != "3" {
+		t.Errorf("parseCommandArgs() = %v, want env=prod count=3", vars)
+	}
+}
+
+func TestParseCommandArgsNamed(t *testing.T) {
+	specs := []models.ArgSpec{
+		{Name: "env", Required: true},
+		{Name: "count", Type: models.ArgTypeInt, Required: false},
+	}
+
+	vars, err := parseCommandArgs("count=3 env=prod", specs)
+	if err != nil {
+		t.Fatalf("parseCommandArgs() returned error: %v", err)
+	}
+
+	if vars["env"] != "prod" || vars["count"] != "3" {
+		t.Errorf("parseCommandArgs() = %v, want env=prod count=3", vars)
+	}
+}
+
+func TestParseCommandArgsMixedNamedAndPositional(t *testing.T) {
+	specs := []models.ArgSpec{
+		{Name: "env", Required: true},
+		{Name: "count", Type: models.ArgTypeInt, Required: false},
+	}
+
+	vars, err := parseCommandArgs("env=prod 3", specs)
+	if err != nil {
+		t.Fatalf("parseCommandArgs() returned error: %v", err)
+	}
+
+	if vars["env"] != "prod" || vars["count"] != "3" {
+		t.Errorf("parseCommandArgs() = %v, want env=prod count=3", vars)
+	}
+}
+
+func TestParseCommandArgsMissingRequired(t *testing.T) {
+	specs := []models.ArgSpec{{Name: "env", Required: true}}
+
+	if _, err := parseCommandArgs("", specs); err == nil {
+		t.Error("parseCommandArgs() with a missing required arg should error")
+	}
+}
+
+func TestParseCommandArgsBadType(t *testing.T) {
+	specs := []models.ArgSpec{{Name: "count", Type: models.ArgTypeInt, Required: true}}
+
+	if _, err := parseCommandArgs("abc", specs); err == nil {
+		t.Error("parseCommandArgs() with a non-int value for an int arg should error")
+	}
+}
+
+func TestValidateArgType(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    models.ArgSpec
+		value   string
+		wantErr bool
+	}{
+		{"valid int", models.ArgSpec{Type: models.ArgTypeInt}, "42", false},
+		{"invalid int", models.ArgSpec{Type: models.ArgTypeInt}, "abc", true},
+		{"valid float", models.ArgSpec{Type: models.ArgTypeFloat}, "4.2", false},
+		{"invalid float", models.ArgSpec{Type: models.ArgTypeFloat}, "abc", true},
+		{"valid bool", models.ArgSpec{Type: models.ArgTypeBool}, "true", false},
+		{"invalid bool", models.ArgSpec{Type: models.ArgTypeBool}, "nope", true},
+		{"untyped always valid", models.ArgSpec{}, "anything", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateArgType(tt.spec, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateArgType(%+v, %q) error = %v, wantErr %v", tt.spec, tt.value, err, tt.wantErr)
+			}
+		})
+	}
+}